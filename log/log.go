@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"net/http"
 	"os"
 	"sync"
+	"time"
 )
 
 const (
@@ -43,7 +45,10 @@ type Logger interface {
 
 // zapLogger 是 Logger 接口的具体实现. 它底层封装了 zap.Logger.
 type zapLogger struct {
-	z *zap.Logger
+	z           *zap.Logger
+	level       zap.AtomicLevel
+	buffers     []*zapcore.BufferedWriteSyncer
+	remoteSinks []Sink
 }
 
 // 确保 zapLogger 实现了 Logger 接口. 以下变量赋值，可以使错误在编译期被发现.
@@ -62,6 +67,23 @@ func Init(opts *Options) {
 	defer mu.Unlock()
 
 	std = NewLogger(opts)
+
+	if opts != nil && opts.EnableLevelHandlerAddr != "" {
+		startLevelHandler(opts.EnableLevelHandlerAddr)
+	}
+}
+
+// startLevelHandler 在独立的 mux 上绑定日志级别读写接口：GET 读取当前级别，
+// PUT body 为 {"level":"debug"} 可在运行时调整级别，无需重启进程.
+func startLevelHandler(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/log/level", LevelHandler())
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			Errorw("log level handler stopped", "err", err)
+		}
+	}()
 }
 
 // NewLogger 根据传入的 opts 创建 Logger.
@@ -70,28 +92,42 @@ func NewLogger(opts *Options) *zapLogger {
 		opts = NewOptions()
 	}
 
-	// 将文本格式的日志级别，例如 info 转换为 zapcore.Level 类型以供后面使用
-	var zapLevel zapcore.Level
-	if err := zapLevel.UnmarshalText([]byte(opts.Level)); err != nil {
-		// 如果指定了非法的日志级别，则默认使用 info 级别
-		zapLevel = zapcore.InfoLevel
-	}
-
 	var cores []zapcore.Core
-	if len(opts.OutputPaths) > 0 {
-		for _, file := range opts.OutputPaths {
-			if err := createDirIfNotExists(file); err != nil {
-				panic(err)
-			}
-			// 获取日志写入位置
-			writeSyncer := getLogWriter(file, opts.Maxsize, opts.MaxBackup, opts.MaxAge)
-			// 获取日志编码格式
-			encoder := getEncoder(opts)
-			// 创建一个将日志写入 WriteSyncer 的核心。
-			fileCore := zapcore.NewCore(encoder, writeSyncer, zapLevel)
-			cores = append(cores, fileCore)
+	var buffers []*zapcore.BufferedWriteSyncer
+	var remoteSinks []Sink
+	for _, sink := range sinksFromOptions(opts) {
+		// Path 带有已注册 scheme（如 kafka://、loki://）时，路由到对应的远程 Sink 而非本地文件
+		if core, s, ok := buildRemoteCore(opts, sink); ok {
+			cores = append(cores, newRedactCore(core, opts.FieldHooks, opts.ScanMessages))
+			remoteSinks = append(remoteSinks, s)
+			continue
 		}
 
+		if err := createDirIfNotExists(sink.Path); err != nil {
+			panic(err)
+		}
+		// 获取日志写入位置
+		writeSyncer := getLogWriter(sink.Path, sink.Maxsize, sink.MaxBackup, sink.MaxAge, sink.Compress)
+		// 开启异步缓冲时，为写入套上一层 BufferedWriteSyncer，减少高频写盘对请求延迟的影响
+		if opts.AsyncBuffer != nil {
+			buffered := &zapcore.BufferedWriteSyncer{
+				WS:            writeSyncer,
+				Size:          opts.AsyncBuffer.Size,
+				FlushInterval: opts.AsyncBuffer.FlushInterval,
+			}
+			writeSyncer = buffered
+			buffers = append(buffers, buffered)
+		}
+		// 获取日志编码格式，Sink 未指定时沿用全局 Format
+		format := sink.Format
+		if format == "" {
+			format = opts.Format
+		}
+		encoder := getEncoder(format)
+		// 按级别区间创建一个将日志写入 WriteSyncer 的核心，并在该叶子 Core 上直接应用脱敏，
+		// 避免脱敏包装在多个 Sink 合并之后导致各 Sink 自身的级别区间失效（见 redactCore 注释）.
+		fileCore := zapcore.NewCore(encoder, writeSyncer, levelEnabler(sink.MinLevel, sink.MaxLevel))
+		cores = append(cores, newRedactCore(fileCore, opts.FieldHooks, opts.ScanMessages))
 	}
 
 	consoleCore := zapcore.NewCore(
@@ -99,17 +135,39 @@ func NewLogger(opts *Options) *zapLogger {
 		zapcore.AddSync(os.Stdout),
 		zap.DebugLevel,
 	)
-	cores = append(cores, consoleCore)
+	cores = append(cores, newRedactCore(consoleCore, opts.FieldHooks, opts.ScanMessages))
+
+	// 将文本格式的日志级别，例如 info 转换为 zapcore.Level 类型，作为运行时可调整的下限
+	var initialLevel zapcore.Level
+	if err := initialLevel.UnmarshalText([]byte(opts.Level)); err != nil {
+		// 如果指定了非法的日志级别，则默认使用 info 级别
+		initialLevel = zapcore.InfoLevel
+	}
+	atomicLevel := zap.NewAtomicLevelAt(initialLevel)
+
+	// 合并 Core，并叠加可运行时调整的 atomicLevel，使其作用于所有 Sink
+	combinedCore, err := zapcore.NewIncreaseLevelCore(zapcore.NewTee(cores...), atomicLevel)
+	if err != nil {
+		panic(err)
+	}
+
+	// 对相同级别/消息的重复日志采样，避免高并发下其拖慢整体请求延迟；
+	// 超出阈值的重复日志会被直接丢弃，仅应用于允许丢失的非关键日志场景.
+	if opts.Sampling != nil {
+		combinedCore = zapcore.NewSamplerWithOptions(combinedCore, time.Second, opts.Sampling.Initial, opts.Sampling.Thereafter)
+	}
 
-	// 合并 Core
-	combinedCore := zapcore.NewTee(cores...)
 	skip := zap.AddCallerSkip(1)
 	if opts.CallerSkip > 0 {
 		skip = zap.AddCallerSkip(opts.CallerSkip)
 	}
 
 	z := zap.New(combinedCore, zap.AddCaller(), skip)
-	logger := &zapLogger{z: z}
+	// StaticFields 作为固定字段附加到每一条日志上
+	for k, v := range opts.StaticFields {
+		z = z.With(zap.String(k, v))
+	}
+	logger := &zapLogger{z: z, level: atomicLevel, buffers: buffers, remoteSinks: remoteSinks}
 
 	// 把标准库的 log.Logger 的 info 级别的输出重定向到 zap.Logger
 	zap.RedirectStdLog(z)
@@ -117,13 +175,65 @@ func NewLogger(opts *Options) *zapLogger {
 	return logger
 }
 
+// SetLevel 在运行时动态调整日志级别，无需重启进程. level 取值同 Options.Level.
+func SetLevel(level string) error { return std.SetLevel(level) }
+
+func (l *zapLogger) SetLevel(level string) error {
+	var lvl zapcore.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return err
+	}
+	l.level.SetLevel(lvl)
+	return nil
+}
+
+// GetLevel 返回当前生效的日志级别.
+func GetLevel() string { return std.GetLevel() }
+
+func (l *zapLogger) GetLevel() string {
+	return l.level.Level().String()
+}
+
+// LevelHandler 返回一个 http.Handler，GET 请求读取当前级别，
+// PUT 请求 body 为 {"level":"debug"} 可运行时修改级别.
+func LevelHandler() http.Handler { return std.LevelHandler() }
+
+func (l *zapLogger) LevelHandler() http.Handler {
+	return l.level
+}
+
 // Sync 调用底层 zap.Logger 的 Sync 方法，将缓存中的日志刷新到磁盘文件中. 主程序需要在退出前调用 Sync.
+// 这也会确定性地 drain 所有 Options.AsyncBuffer 产生的缓冲写入.
 func Sync() { std.Sync() }
 
 func (l *zapLogger) Sync() {
 	_ = l.z.Sync()
 }
 
+// Close 停止 Options.AsyncBuffer 启用时后台 flush 的 goroutine，关闭所有远程 Sink
+// （Kafka/Loki 等），并做一次 Sync 保证缓冲数据落盘. 使用了 AsyncBuffer 或远程 Sink 的场景下，
+// 应在进程退出前调用 Close 以回收对应的 goroutine/连接.
+func Close() error { return std.Close() }
+
+func (l *zapLogger) Close() error {
+	for _, b := range l.buffers {
+		b.Stop()
+	}
+
+	// 控制台 core 在 stdout 不是普通文件时 Sync 经常返回 "invalid argument"，
+	// 这是 zap 的已知现象而非真实错误，因此和 Sync() 一样忽略该返回值.
+	_ = l.z.Sync()
+
+	var err error
+	for _, s := range l.remoteSinks {
+		if closeErr := s.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	return err
+}
+
 // Debugw 输出 debug 级别的日志.
 func Debugw(msg string, keysAndValues ...interface{}) {
 	std.z.Sugar().Debugw(msg, keysAndValues...)
@@ -209,6 +319,38 @@ func FatalfWithContext(c context.Context, format string, args ...interface{}) {
 	C(c).Fatalw(msg) // 强制转为结构化日志
 }
 
+var (
+	contextFieldsMu sync.RWMutex
+	// contextFields 记录通过 RegisterContextField 注册的、按固定 key 提取的 context 字段
+	contextFields []contextFieldRegistration
+	// contextHooks 记录通过 RegisterContextHook 注册的、需要访问整个 context 的提取器
+	// （例如 OpenTelemetry 的 SpanContext 并不挂在一个可直接 Value() 的 key 下）
+	contextHooks []func(ctx context.Context) []zap.Field
+)
+
+type contextFieldRegistration struct {
+	key       any
+	logKey    string
+	extractor func(any) zap.Field
+}
+
+// RegisterContextField 注册一个 context 字段提取器. C(ctx) 会在生成日志前按注册顺序遍历所有提取器，
+// 当 ctx.Value(key) 不为 nil 时，通过 extractor 将其转换为 zap.Field 并附加到日志中.
+// logKey 仅用于标识该注册项，便于排查重复注册，不会被自动传入 extractor.
+func RegisterContextField(key any, logKey string, extractor func(any) zap.Field) {
+	contextFieldsMu.Lock()
+	defer contextFieldsMu.Unlock()
+	contextFields = append(contextFields, contextFieldRegistration{key: key, logKey: logKey, extractor: extractor})
+}
+
+// RegisterContextHook 注册一个需要访问完整 context 的字段提取器，适用于像 OpenTelemetry
+// SpanContext 这类不经由单一 key 暴露的场景. hook 返回的字段会按顺序附加到日志中.
+func RegisterContextHook(hook func(ctx context.Context) []zap.Field) {
+	contextFieldsMu.Lock()
+	defer contextFieldsMu.Unlock()
+	contextHooks = append(contextHooks, hook)
+}
+
 // C 解析传入的 context，尝试提取关注的键值，并添加到 zap.Logger 结构化日志中.
 func C(ctx context.Context) *zapLogger {
 	return std.C(ctx)
@@ -221,9 +363,34 @@ func (l *zapLogger) C(ctx context.Context) *zapLogger {
 		lc.z = lc.z.With(zap.Any(RequestId, requestID))
 	}
 
+	contextFieldsMu.RLock()
+	defer contextFieldsMu.RUnlock()
+
+	for _, reg := range contextFields {
+		if v := ctx.Value(reg.key); v != nil {
+			lc.z = lc.z.With(reg.extractor(v))
+		}
+	}
+
+	for _, hook := range contextHooks {
+		for _, f := range hook(ctx) {
+			lc.z = lc.z.With(f)
+		}
+	}
+
 	return lc
 }
 
+// WithContext 返回一个绑定了 ctx 中已注册字段的 Logger. 相比每次调用都重新解析 context 的
+// XxxWithContext 系列函数，适合在热路径中复用同一个绑定后的 Logger.
+func WithContext(ctx context.Context) Logger {
+	return std.WithContext(ctx)
+}
+
+func (l *zapLogger) WithContext(ctx context.Context) Logger {
+	return l.C(ctx)
+}
+
 // clone 深度拷贝 zapLogger.
 func (l *zapLogger) clone() *zapLogger {
 	lc := *l
@@ -22,6 +22,9 @@ type Options struct {
 	DisableCaller bool
 	// 是否禁止在 panic 及以上级别打印堆栈信息
 	DisableStacktrace bool
+	// CallerSkip 指定在 caller 信息中跳过的调用栈层数，大于 0 时覆盖默认的 1 层跳过.
+	// 用于封装了本包的上层日志门面，使 caller 仍然指向业务代码而非门面本身.
+	CallerSkip int
 	// 指定日志级别，可选值：debug, info, warn, error, dpanic, panic, fatal
 	Level string
 	// 指定日志显示格式，可选值：console, json
@@ -38,6 +41,65 @@ type Options struct {
 	LocalTime bool
 	// 是否压缩/归档旧文件
 	Compress bool
+	// Sinks 按级别区间将日志路由到不同的文件，每个 Sink 拥有独立的切割参数和编码格式.
+	// 例如 info/warn 输出到 app.log、error 及以上单独输出到 error.log 以便接入告警.
+	// 控制台输出由固定的 debug 级别控制台 core 负责，不经由 Sinks.
+	// Sinks 与 OutputPaths 同时生效：OutputPaths 中的每一项会按兼容模式追加为一个 Sink.
+	Sinks []SinkOption
+	// EnableLevelHandlerAddr 不为空时，Init 会在该地址上启动一个独立的 mux，
+	// 暴露 /log/level 接口用于运行时读取（GET）和修改（PUT）日志级别.
+	EnableLevelHandlerAddr string
+	// Sampling 不为空时对日志进行采样，避免高并发下重复的相同级别/消息日志拖慢请求延迟.
+	// 采样会丢弃超出阈值的重复日志，因此只应用于非关键、可能高频重复的日志场景.
+	Sampling *SamplingOption
+	// AsyncBuffer 不为空时为每个文件 Sink 的写入套上一层异步缓冲，减少高频写盘对延迟的影响.
+	// 使用该选项后，进程退出前需调用 Close 以停止后台 flush goroutine并落盘剩余数据.
+	AsyncBuffer *AsyncBufferOption
+	// StaticFields 是附加到每一条日志上的固定字段，例如 service、env. 对于通过 RegisterSink
+	// 接入的远程 Sink（如 Loki），这些字段还会被作为流标签（label）传给对应的 Sink 工厂.
+	StaticFields map[string]string
+	// FieldHooks 在字段写入前依次对其进行改写，常用于脱敏之外的自定义字段处理.
+	// 与 RegisterRedactor 的区别是：FieldHooks 绑定在单个 Logger 上，RegisterRedactor 是全局规则.
+	FieldHooks []func(zapcore.Field) zapcore.Field
+	// ScanMessages 为 true 时，对日志正文进行敏感信息扫描（信用卡号、JWT、邮箱等常见模式）并脱敏.
+	// 该扫描基于正则与 Luhn 校验，有一定性能开销，默认关闭.
+	ScanMessages bool
+}
+
+// SamplingOption 对应 zapcore.NewSamplerWithOptions 的采样参数，统计周期固定为 1 秒.
+type SamplingOption struct {
+	// Initial 每秒内，相同级别和消息的日志，前 Initial 条全部记录
+	Initial int
+	// Thereafter 超过 Initial 条之后，每 Thereafter 条才记录 1 条，其余丢弃
+	Thereafter int
+}
+
+// AsyncBufferOption 配置文件 WriteSyncer 的异步缓冲.
+type AsyncBufferOption struct {
+	// Size 缓冲区大小（字节），超过后自动 flush，为 0 时使用 zapcore 默认值
+	Size int
+	// FlushInterval 定时 flush 的间隔，为 0 时使用 zapcore 默认值
+	FlushInterval time.Duration
+}
+
+// SinkOption 描述一个按级别区间路由的日志输出目标.
+type SinkOption struct {
+	// Path 指定该 Sink 的日志文件路径
+	Path string
+	// MinLevel 指定该 Sink 接收的最低级别（含），为空时默认为 debug
+	MinLevel string
+	// MaxLevel 指定该 Sink 接收的最高级别（含），为空表示不设上限
+	MaxLevel string
+	// Format 指定该 Sink 的日志格式，可选值：console, json，为空时沿用 Options.Format
+	Format string
+	// Maxsize 文件的最大大小（MB），超过后进行切割
+	Maxsize int
+	// MaxBackup 旧日志保持的最大个数
+	MaxBackup int
+	// MaxAge 文件保持的最大天数
+	MaxAge int
+	// Compress 是否压缩/归档旧文件
+	Compress bool
 }
 
 // NewOptions 创建一个带有默认参数的 Options 对象.
@@ -56,8 +118,8 @@ func NewOptions() *Options {
 	}
 }
 
-// 负责设置 encoding 的日志格式
-func getEncoder() zapcore.Encoder {
+// 负责设置 encoding 的日志格式. format 为 "console" 时输出控制台友好格式，其余（含空值）输出 json.
+func getEncoder(format string) zapcore.Encoder {
 	// 获取一个指定的的EncoderConfig，进行自定义
 	encodeConfig := zap.NewProductionEncoderConfig()
 
@@ -77,11 +139,15 @@ func getEncoder() zapcore.Encoder {
 	// 以 package/file:行 的格式 序列化调用程序，从完整路径中删除除最后一个目录外的所有目录。
 	encodeConfig.EncodeCaller = zapcore.ShortCallerEncoder
 
+	if format == "console" {
+		return zapcore.NewConsoleEncoder(encodeConfig)
+	}
+
 	return zapcore.NewJSONEncoder(encodeConfig)
 }
 
 // 负责日志写入的位置
-func getLogWriter(filename string, maxsize, maxBackup, maxAge int) zapcore.WriteSyncer {
+func getLogWriter(filename string, maxsize, maxBackup, maxAge int, compress bool) zapcore.WriteSyncer {
 	if len(filename) == 0 {
 		filename = filepath.Clean(filepath.Dir(logFilePath)) + string(filepath.Separator) + filepath.Base(logFilePath)
 	}
@@ -101,12 +167,65 @@ func getLogWriter(filename string, maxsize, maxBackup, maxAge int) zapcore.Write
 		MaxAge:     maxAge,    // 保留旧文件的最大天数
 		MaxBackups: maxBackup, // 保留旧文件的最大个数
 		LocalTime:  true,      // 是否按照天保留
-		Compress:   false,     // 是否压缩/归档旧文件
+		Compress:   compress,  // 是否压缩/归档旧文件
 	}
 
 	return zapcore.AddSync(lumberJackLogger)
 }
 
+// levelEnabler 根据 [minLevel, maxLevel] 区间构造一个 zap.LevelEnablerFunc，
+// minLevel 非法或为空时默认为 debug，maxLevel 为空表示不设上限.
+func levelEnabler(minLevel, maxLevel string) zap.LevelEnablerFunc {
+	min := zapcore.DebugLevel
+	if minLevel != "" {
+		// zap 会把空字符串解析为 info，因此只在非空时解析
+		_ = min.UnmarshalText([]byte(minLevel))
+	}
+
+	var max zapcore.Level
+	hasMax := maxLevel != "" && max.UnmarshalText([]byte(maxLevel)) == nil
+
+	return func(lvl zapcore.Level) bool {
+		if lvl < min {
+			return false
+		}
+		if hasMax && lvl > max {
+			return false
+		}
+		return true
+	}
+}
+
+// sinksFromOptions 汇总 opts.Sinks 与 opts.OutputPaths（兼容模式）得到最终的 Sink 列表.
+// OutputPaths 中的每一项会按 opts.Level 作为下限、不设上限，沿用 opts 中的格式与切割参数.
+func sinksFromOptions(opts *Options) []SinkOption {
+	sinks := make([]SinkOption, 0, len(opts.Sinks)+len(opts.OutputPaths))
+	sinks = append(sinks, opts.Sinks...)
+
+	for _, path := range opts.OutputPaths {
+		// opts.Format 是面向本地文件/终端的格式配置（默认 "console"），对 kafka://、loki://
+		// 这类已注册 scheme 的远程 Sink 没有意义：buildRemoteCore 只认 json，留空才会按 json
+		// 处理. 因此 OutputPaths 中解析为远程 Sink 的条目不应继承 opts.Format，否则默认配置下
+		// 就会因 "console" 被当成显式指定的非法 Format 而在 NewLogger 时直接 panic.
+		format := opts.Format
+		if isRemoteSinkPath(path) {
+			format = ""
+		}
+
+		sinks = append(sinks, SinkOption{
+			Path:      path,
+			MinLevel:  opts.Level,
+			Format:    format,
+			Maxsize:   opts.Maxsize,
+			MaxBackup: opts.MaxBackup,
+			MaxAge:    opts.MaxAge,
+			Compress:  opts.Compress,
+		})
+	}
+
+	return sinks
+}
+
 func createDirIfNotExists(logFilePath string) error {
 	dir := filepath.Dir(logFilePath)
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
@@ -0,0 +1,27 @@
+package log
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+func init() {
+	RegisterContextHook(otelTraceFields)
+}
+
+// otelTraceFields 从 ctx 中提取 OpenTelemetry SpanContext，生成 trace_id/span_id 字段，
+// 用于在 Grafana/Loki 等系统中实现日志与链路追踪的关联. SpanContext 无效（即 ctx 中没有
+// 正在进行的 span）时不附加任何字段.
+func otelTraceFields(ctx context.Context) []zap.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+
+	return []zap.Field{
+		zap.String("trace_id", sc.TraceID().String()),
+		zap.String("span_id", sc.SpanID().String()),
+	}
+}
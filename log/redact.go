@@ -0,0 +1,143 @@
+package log
+
+import (
+	"regexp"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+type redactorRegistration struct {
+	pattern  *regexp.Regexp
+	replacer func(string) string
+}
+
+var (
+	redactorsMu sync.RWMutex
+	// redactors 记录通过 RegisterRedactor 注册的、按字段 key 匹配的脱敏规则，全局生效.
+	redactors []redactorRegistration
+)
+
+// RegisterRedactor 注册一条字段脱敏规则：字段 key 匹配 keyPattern（正则，如 "(?i)password"、
+// "(?i)token"、"(?i)email"）时，其字符串值会在写入前经 replacer 改写. 规则全局生效，
+// 影响此后创建以及已创建的所有 Logger.
+func RegisterRedactor(keyPattern string, replacer func(string) string) {
+	redactorsMu.Lock()
+	defer redactorsMu.Unlock()
+	redactors = append(redactors, redactorRegistration{
+		pattern:  regexp.MustCompile(keyPattern),
+		replacer: replacer,
+	})
+}
+
+// redactField 依次应用所有已注册的 RegisterRedactor 规则. 仅对字符串类型的字段生效.
+func redactField(f zapcore.Field) zapcore.Field {
+	if f.Type != zapcore.StringType {
+		return f
+	}
+
+	redactorsMu.RLock()
+	defer redactorsMu.RUnlock()
+
+	for _, r := range redactors {
+		if r.pattern.MatchString(f.Key) {
+			f.String = r.replacer(f.String)
+		}
+	}
+
+	return f
+}
+
+var (
+	jwtPattern   = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+	emailPattern = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+	cardPattern  = regexp.MustCompile(`\b(?:[0-9][ -]?){13,19}\b`)
+)
+
+// scanMessage 在 Options.ScanMessages 开启时对日志正文做常见敏感信息脱敏：
+// JWT、邮箱直接按模式替换；数字串按 Luhn 校验通过后才当作信用卡号脱敏，避免误伤普通数字.
+func scanMessage(msg string) string {
+	msg = jwtPattern.ReplaceAllString(msg, "***REDACTED-JWT***")
+	msg = emailPattern.ReplaceAllString(msg, "***REDACTED-EMAIL***")
+	msg = cardPattern.ReplaceAllStringFunc(msg, func(s string) string {
+		if isLuhnValid(s) {
+			return "***REDACTED-CARD***"
+		}
+		return s
+	})
+
+	return msg
+}
+
+// isLuhnValid 对去除了空格/连字符的数字串做 Luhn 校验.
+func isLuhnValid(number string) bool {
+	sum := 0
+	alt := false
+	digits := 0
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		d := int(c - '0')
+		if alt {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		alt = !alt
+		digits++
+	}
+
+	return digits > 0 && sum%10 == 0
+}
+
+// redactCore 包装单个叶子 Core（文件/远程 Sink/控制台），在写入前对字段应用
+// RegisterRedactor 规则与 Options.FieldHooks，并在 scanMessages 开启时对日志正文做敏感
+// 信息扫描. 必须在 zapcore.NewTee 合并前逐个包装叶子 Core，而不是包装合并后的 Tee：
+// multiCore.Write 会无条件转发给所有子 Core，只有每个叶子自身的 Check 才承担按级别路由
+// 的职责；若改为包装在 Tee 之外，redactCore 自行注册为 Write 目标会绕过其余叶子的级别判断，
+// 导致不该命中某个 Sink 的日志也被写入.
+type redactCore struct {
+	zapcore.Core
+	hooks        []func(zapcore.Field) zapcore.Field
+	scanMessages bool
+}
+
+func newRedactCore(core zapcore.Core, hooks []func(zapcore.Field) zapcore.Field, scanMessages bool) zapcore.Core {
+	return &redactCore{Core: core, hooks: hooks, scanMessages: scanMessages}
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.applyHooks(fields)), hooks: c.hooks, scanMessages: c.scanMessages}
+}
+
+func (c *redactCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if c.scanMessages {
+		entry.Message = scanMessage(entry.Message)
+	}
+
+	return c.Core.Write(entry, c.applyHooks(fields))
+}
+
+func (c *redactCore) applyHooks(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		f = redactField(f)
+		for _, hook := range c.hooks {
+			f = hook(f)
+		}
+		out[i] = f
+	}
+
+	return out
+}